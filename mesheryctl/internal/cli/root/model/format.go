@@ -0,0 +1,206 @@
+package model
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cheggaaa/pb/v3"
+	"github.com/layer5io/meshery/server/handlers"
+	meshkitutils "github.com/layer5io/meshkit/utils"
+)
+
+const (
+	formatTarGz = "tar.gz"
+	formatZip   = "zip"
+	formatOCI   = "oci"
+)
+
+// meshModelLayerMediaType is the OCI layer media type compressOCILayout
+// extracts and uploads as-is.
+const meshModelLayerMediaType = "application/vnd.meshery.model.v1+tar"
+
+var archiveFormats = map[string]bool{
+	formatTarGz: true,
+	formatZip:   true,
+	formatOCI:   true,
+}
+
+// detectArchiveFormat infers the archive format from a directory's contents
+// when --format isn't given: an OCI image layout is recognized by its
+// oci-layout marker file, anything else falls back to tar.gz.
+func detectArchiveFormat(dirpath string) string {
+	if _, err := os.Stat(filepath.Join(dirpath, "oci-layout")); err == nil {
+		return formatOCI
+	}
+	return formatTarGz
+}
+
+// compressDirectoryZip packs dirpath into a zip archive, preserving relative
+// paths the same way writeTarGz does for tar.gz.
+func compressDirectoryZip(ctx context.Context, dirpath string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	var bar *pb.ProgressBar
+	if progressBarsEnabled() {
+		var totalSize int64
+		err := filepath.Walk(dirpath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return meshkitutils.ErrFileWalkDir(err, path)
+			}
+			if !info.IsDir() {
+				totalSize += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		bar = newByteProgressBar("Compressing", totalSize)
+		defer bar.Finish()
+	}
+
+	err := filepath.Walk(dirpath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return meshkitutils.ErrFileWalkDir(err, path)
+		}
+		if ctx.Err() != nil {
+			return ctxError(ctx)
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(filepath.Dir(dirpath), path)
+		if err != nil {
+			return meshkitutils.ErrRelPath(err, path)
+		}
+
+		w, err := zw.Create(filepath.ToSlash(relPath))
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return handlers.ErrOpenFile(path)
+		}
+		defer file.Close()
+
+		written, err := io.Copy(w, file)
+		if err != nil {
+			return meshkitutils.ErrCopyFile(err)
+		}
+
+		if bar != nil {
+			bar.Add64(written)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, meshkitutils.ErrCloseFile(err)
+	}
+	return buf.Bytes(), nil
+}
+
+type ociIndex struct {
+	Manifests []ociDescriptor `json:"manifests"`
+}
+
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+// compressOCILayout reads an OCI image layout directory and returns the
+// bytes of the layer tagged meshModelLayerMediaType, rather than
+// re-archiving the whole layout.
+func compressOCILayout(ctx context.Context, dirpath string) ([]byte, error) {
+	if ctx.Err() != nil {
+		return nil, ctxError(ctx)
+	}
+
+	indexData, err := os.ReadFile(filepath.Join(dirpath, "index.json"))
+	if err != nil {
+		return nil, fmt.Errorf("not a valid OCI image layout (missing index.json): %v", err)
+	}
+	var index ociIndex
+	if err := json.Unmarshal(indexData, &index); err != nil {
+		return nil, fmt.Errorf("could not parse OCI index.json: %v", err)
+	}
+	if len(index.Manifests) == 0 {
+		return nil, fmt.Errorf("OCI index.json lists no manifests")
+	}
+
+	manifestData, err := os.ReadFile(ociBlobPath(dirpath, index.Manifests[0].Digest))
+	if err != nil {
+		return nil, fmt.Errorf("could not read OCI manifest: %v", err)
+	}
+	var manifest ociManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("could not parse OCI manifest: %v", err)
+	}
+
+	for _, layer := range manifest.Layers {
+		if ctx.Err() != nil {
+			return nil, ctxError(ctx)
+		}
+		if layer.MediaType != meshModelLayerMediaType {
+			continue
+		}
+		return readBlobWithProgress(ociBlobPath(dirpath, layer.Digest))
+	}
+	return nil, fmt.Errorf("no layer with media type %q found in OCI manifest", meshModelLayerMediaType)
+}
+
+// readBlobWithProgress reads an OCI blob, rendering the same "Compressing"
+// progress bar compressDirectoryZip shows for zip directory imports
+// (compressOCILayout has nothing to "compress" since the blob is
+// already an archived layer, but the read itself can still take a while for
+// a large model).
+func readBlobWithProgress(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, handlers.ErrOpenFile(path)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if progressBarsEnabled() {
+		info, err := file.Stat()
+		if err != nil {
+			return nil, err
+		}
+		bar := newByteProgressBar("Compressing", info.Size())
+		defer bar.Finish()
+		reader = bar.NewProxyReader(reader)
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, reader); err != nil {
+		return nil, meshkitutils.ErrCopyFile(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ociBlobPath resolves an OCI "<algo>:<hash>" digest to its path under the
+// layout's blobs directory.
+func ociBlobPath(dirpath, digest string) string {
+	algo, hash, _ := strings.Cut(digest, ":")
+	return filepath.Join(dirpath, "blobs", algo, hash)
+}
@@ -0,0 +1,117 @@
+package model
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetectArchiveFormat(t *testing.T) {
+	t.Run("oci layout marker present", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "oci-layout"), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if got := detectArchiveFormat(dir); got != formatOCI {
+			t.Errorf("detectArchiveFormat() = %q, want %q", got, formatOCI)
+		}
+	})
+
+	t.Run("no marker falls back to tar.gz", func(t *testing.T) {
+		dir := t.TempDir()
+		if got := detectArchiveFormat(dir); got != formatTarGz {
+			t.Errorf("detectArchiveFormat() = %q, want %q", got, formatTarGz)
+		}
+	})
+}
+
+func TestOciBlobPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		digest string
+		want   string
+	}{
+		{name: "sha256 digest", digest: "sha256:abc123", want: filepath.Join("/layout", "blobs", "sha256", "abc123")},
+		{name: "no algo separator", digest: "abc123", want: filepath.Join("/layout", "blobs", "abc123", "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ociBlobPath("/layout", tt.digest); got != tt.want {
+				t.Errorf("ociBlobPath(%q) = %q, want %q", tt.digest, got, tt.want)
+			}
+		})
+	}
+}
+
+func writeBlob(t *testing.T, dir, digest string, data []byte) {
+	t.Helper()
+	algo, hash, _ := strings.Cut(digest, ":")
+	blobDir := filepath.Join(dir, "blobs", algo)
+	if err := os.MkdirAll(blobDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(blobDir, hash), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCompressOCILayout(t *testing.T) {
+	t.Run("missing index.json", func(t *testing.T) {
+		dir := t.TempDir()
+		if _, err := compressOCILayout(context.Background(), dir); err == nil {
+			t.Error("expected an error for a missing index.json, got nil")
+		}
+	})
+
+	t.Run("malformed index.json", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "index.json"), []byte("not json"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := compressOCILayout(context.Background(), dir); err == nil {
+			t.Error("expected an error for a malformed index.json, got nil")
+		}
+	})
+
+	t.Run("no manifests listed", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "index.json"), []byte(`{"manifests":[]}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := compressOCILayout(context.Background(), dir); err == nil {
+			t.Error("expected an error when index.json lists no manifests, got nil")
+		}
+	})
+
+	t.Run("no layer with the expected media type", func(t *testing.T) {
+		dir := t.TempDir()
+		writeBlob(t, dir, "sha256:manifestdigest", []byte(`{"layers":[{"mediaType":"application/vnd.other.layer","digest":"sha256:layerdigest"}]}`))
+		writeBlob(t, dir, "sha256:layerdigest", []byte("layer bytes"))
+		if err := os.WriteFile(filepath.Join(dir, "index.json"), []byte(`{"manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:manifestdigest"}]}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := compressOCILayout(context.Background(), dir); err == nil {
+			t.Error("expected an error when no layer matches meshModelLayerMediaType, got nil")
+		}
+	})
+
+	t.Run("matching layer is returned", func(t *testing.T) {
+		dir := t.TempDir()
+		want := []byte("the model tar bytes")
+		writeBlob(t, dir, "sha256:manifestdigest", []byte(`{"layers":[{"mediaType":"`+meshModelLayerMediaType+`","digest":"sha256:layerdigest"}]}`))
+		writeBlob(t, dir, "sha256:layerdigest", want)
+		if err := os.WriteFile(filepath.Join(dir, "index.json"), []byte(`{"manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:manifestdigest"}]}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := compressOCILayout(context.Background(), dir)
+		if err != nil {
+			t.Fatalf("compressOCILayout() error = %v", err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("compressOCILayout() = %q, want %q", got, want)
+		}
+	})
+}
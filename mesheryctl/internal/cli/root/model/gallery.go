@@ -0,0 +1,249 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/layer5io/meshery/mesheryctl/pkg/utils"
+	meshkitutils "github.com/layer5io/meshkit/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Gallery is a named, indexed catalog of importable models, configured under
+// the "galleries" key in the mesheryctl config file.
+type Gallery struct {
+	Name     string `mapstructure:"name" yaml:"name" json:"name"`
+	IndexURL string `mapstructure:"indexURL" yaml:"indexURL" json:"indexURL"`
+}
+
+// GalleryEntry is a single model listed in a gallery index.
+type GalleryEntry struct {
+	Name        string   `yaml:"name" json:"name"`
+	URLs        []string `yaml:"urls" json:"urls"`
+	Tags        []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Version     string   `yaml:"version,omitempty" json:"version,omitempty"`
+}
+
+// galleryTimeout bounds the index fetch in 'gallery list'/'gallery apply' and,
+// for 'apply', the register submit request, mirroring importTimeout/jobsTimeout.
+var galleryTimeout time.Duration
+
+var galleryCmd = &cobra.Command{
+	Use:   "gallery",
+	Short: "Browse and apply models from a configured gallery",
+	Long:  "A gallery is a curated index of models (similar to a package manager index). Configure one or more galleries under the 'galleries' key in the mesheryctl config file, then use 'list' and 'apply' to discover and import models without knowing their source location in advance.",
+}
+
+var galleryListCmd = &cobra.Command{
+	Use:   "list [gallery-name]",
+	Short: "List the models available in a gallery",
+	Long:  "Fetch a gallery's index (cached locally under ~/.meshery/gallery, revalidated with ETag) and print the models it offers. If the mesheryctl config defines only one gallery, [gallery-name] may be omitted.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gallery, err := resolveGallery(args)
+		if err != nil {
+			return err
+		}
+
+		signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		ctx, cancel := context.WithTimeout(signalCtx, galleryTimeout)
+		defer cancel()
+
+		entries, err := fetchGalleryIndex(ctx, gallery)
+		if err != nil {
+			return err
+		}
+
+		header := []string{"Name", "Version", "Tags", "Description"}
+		rows := [][]string{}
+		for _, entry := range entries {
+			rows = append(rows, []string{entry.Name, entry.Version, strings.Join(entry.Tags, ", "), entry.Description})
+		}
+		utils.PrintToTable(header, rows)
+		return nil
+	},
+}
+
+var galleryApplyCmd = &cobra.Command{
+	Use:   "apply <model-name>[@version]",
+	Short: "Register a model listed in a gallery",
+	Long:  "Resolve a gallery entry to its source URLs and register it, rendering the response the same way as 'model import --url'.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gallery, err := resolveGallery(nil)
+		if err != nil {
+			return err
+		}
+
+		signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		fetchCtx, cancel := context.WithTimeout(signalCtx, galleryTimeout)
+		entries, err := fetchGalleryIndex(fetchCtx, gallery)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		name, version := splitNameVersion(args[0])
+		entry, err := findGalleryEntry(entries, name, version)
+		if err != nil {
+			return err
+		}
+
+		for _, rawURL := range entry.URLs {
+			if err := validateURLScheme(rawURL); err != nil {
+				return err
+			}
+		}
+
+		submitCtx, cancel := context.WithTimeout(signalCtx, galleryTimeout)
+		defer cancel()
+		return registerModelURLs(submitCtx, signalCtx, entry.URLs)
+	},
+}
+
+// resolveGallery picks the gallery to operate on: the one named in args, or
+// the sole configured gallery when there's exactly one.
+func resolveGallery(args []string) (Gallery, error) {
+	var galleries []Gallery
+	if err := viper.UnmarshalKey("galleries", &galleries); err != nil {
+		return Gallery{}, fmt.Errorf("could not read galleries from mesheryctl config: %v", err)
+	}
+	if len(galleries) == 0 {
+		return Gallery{}, fmt.Errorf("no galleries configured; add one under 'galleries' in the mesheryctl config file")
+	}
+
+	if len(args) == 0 || args[0] == "" {
+		if len(galleries) > 1 {
+			return Gallery{}, fmt.Errorf("multiple galleries configured; specify one of the configured gallery names")
+		}
+		return galleries[0], nil
+	}
+
+	for _, gallery := range galleries {
+		if gallery.Name == args[0] {
+			return gallery, nil
+		}
+	}
+	return Gallery{}, fmt.Errorf("gallery %q is not configured", args[0])
+}
+
+// galleryCacheDir returns (creating if necessary) the directory gallery
+// indexes are cached under. Each gallery gets its own <name>.yaml plus a
+// sibling <name>.etag, so fetchGalleryIndex can revalidate with an
+// If-None-Match instead of re-downloading an unchanged index on every call.
+func galleryCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".meshery", "gallery")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// fetchGalleryIndex fetches a gallery's index, revalidating against the
+// locally cached copy with an ETag so repeated calls don't re-download an
+// unchanged index. ctx bounds the fetch so a hung gallery server doesn't
+// block 'gallery list'/'gallery apply' forever.
+func fetchGalleryIndex(ctx context.Context, gallery Gallery) ([]GalleryEntry, error) {
+	cacheDir, err := galleryCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	indexPath := filepath.Join(cacheDir, gallery.Name+".yaml")
+	etagPath := filepath.Join(cacheDir, gallery.Name+".etag")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gallery.IndexURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if etag, err := os.ReadFile(etagPath); err == nil {
+		req.Header.Set("If-None-Match", strings.TrimSpace(string(etag)))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctxError(ctx)
+		}
+		return nil, fmt.Errorf("could not fetch gallery index %q: %v", gallery.IndexURL, err)
+	}
+	defer resp.Body.Close()
+
+	var body []byte
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		body, err = os.ReadFile(indexPath)
+		if err != nil {
+			return nil, fmt.Errorf("gallery index cache for %q is missing; re-run without a cached ETag: %v", gallery.Name, err)
+		}
+	case http.StatusOK:
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("could not read gallery index %q: %v", gallery.IndexURL, err)
+		}
+		if err := os.WriteFile(indexPath, body, 0644); err != nil {
+			return nil, err
+		}
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			if err := os.WriteFile(etagPath, []byte(etag), 0644); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("fetching gallery index %q returned status %d", gallery.IndexURL, resp.StatusCode)
+	}
+
+	var entries []GalleryEntry
+	if err := meshkitutils.Unmarshal(string(body), &entries); err != nil {
+		return nil, fmt.Errorf("could not parse gallery index %q: %v", gallery.Name, err)
+	}
+	return entries, nil
+}
+
+// splitNameVersion splits "name@version" into its parts; version is empty
+// when unspecified.
+func splitNameVersion(s string) (string, string) {
+	name, version, found := strings.Cut(s, "@")
+	if !found {
+		return s, ""
+	}
+	return name, version
+}
+
+func findGalleryEntry(entries []GalleryEntry, name, version string) (GalleryEntry, error) {
+	for _, entry := range entries {
+		if entry.Name != name {
+			continue
+		}
+		if version == "" || entry.Version == version {
+			return entry, nil
+		}
+	}
+	if version != "" {
+		return GalleryEntry{}, fmt.Errorf("model %q@%q not found in gallery index", name, version)
+	}
+	return GalleryEntry{}, fmt.Errorf("model %q not found in gallery index", name)
+}
+
+func init() {
+	galleryCmd.PersistentFlags().DurationVar(&galleryTimeout, "timeout", 30*time.Second, "Time to allow the gallery index fetch (and, for 'apply', the register request) to complete before aborting")
+	galleryCmd.AddCommand(galleryListCmd)
+	galleryCmd.AddCommand(galleryApplyCmd)
+	ModelCmd.AddCommand(galleryCmd)
+}
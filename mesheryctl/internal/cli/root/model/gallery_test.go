@@ -0,0 +1,62 @@
+package model
+
+import "testing"
+
+func TestSplitNameVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		wantName    string
+		wantVersion string
+	}{
+		{name: "no version", in: "istio", wantName: "istio", wantVersion: ""},
+		{name: "with version", in: "istio@1.2.3", wantName: "istio", wantVersion: "1.2.3"},
+		{name: "empty version after @", in: "istio@", wantName: "istio", wantVersion: ""},
+		{name: "multiple @", in: "istio@1.2.3@beta", wantName: "istio", wantVersion: "1.2.3@beta"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotName, gotVersion := splitNameVersion(tt.in)
+			if gotName != tt.wantName || gotVersion != tt.wantVersion {
+				t.Errorf("splitNameVersion(%q) = (%q, %q), want (%q, %q)", tt.in, gotName, gotVersion, tt.wantName, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestFindGalleryEntry(t *testing.T) {
+	entries := []GalleryEntry{
+		{Name: "istio", Version: "1.2.3"},
+		{Name: "istio", Version: "1.4.0"},
+		{Name: "kuma", Version: "1.0.0"},
+	}
+
+	tests := []struct {
+		name        string
+		entryName   string
+		version     string
+		wantVersion string
+		wantErr     bool
+	}{
+		{name: "exact match", entryName: "istio", version: "1.2.3", wantVersion: "1.2.3"},
+		{name: "unversioned returns first match", entryName: "istio", version: "", wantVersion: "1.2.3"},
+		{name: "unknown version", entryName: "istio", version: "9.9.9", wantErr: true},
+		{name: "unknown name", entryName: "linkerd", version: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := findGalleryEntry(entries, tt.entryName, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("findGalleryEntry(%q, %q) error = %v, wantErr %v", tt.entryName, tt.version, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if entry.Version != tt.wantVersion {
+				t.Errorf("findGalleryEntry(%q, %q) version = %q, want %q", tt.entryName, tt.version, entry.Version, tt.wantVersion)
+			}
+		})
+	}
+}
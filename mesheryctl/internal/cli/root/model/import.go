@@ -1,16 +1,24 @@
 package model
 
 import (
+	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/cheggaaa/pb/v3"
 	"github.com/layer5io/meshery/mesheryctl/internal/cli/root/config"
 	"github.com/layer5io/meshery/mesheryctl/pkg/utils"
 	"github.com/layer5io/meshery/server/handlers"
@@ -19,6 +27,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 )
 
 type ImportRequestBody struct {
@@ -28,9 +37,26 @@ type ImportRequestBody struct {
 		FileName  string `json:"file_name,omitempty"`
 	} `json:"importBody"`
 	UploadType string `json:"uploadType"`
+	Async      bool   `json:"async,omitempty"`
 }
 
-var location string
+var (
+	location      string
+	quiet         bool
+	noProgress    bool
+	modelURLs     []string
+	asyncImport   bool
+	waitForJob    bool
+	importTimeout time.Duration
+	archiveFormat string
+)
+
+// allowedURLSchemes are the schemes importModelCmd will fetch models from
+// when invoked with --url.
+var allowedURLSchemes = map[string]bool{
+	"http":  true,
+	"https": true,
+}
 
 var importModelCmd = &cobra.Command{
 	Use:   "import",
@@ -41,7 +67,16 @@ var importModelCmd = &cobra.Command{
 	import model --file /path/to/models
 	`,
 	Args: func(_ *cobra.Command, args []string) error {
-		const errMsg = "Usage: mesheryctl model import [ file | filePath ]\nRun 'mesheryctl model import --help' to see detailed help message"
+		const errMsg = "Usage: mesheryctl model import [ file | filePath ] or mesheryctl model import --url [url]\nRun 'mesheryctl model import --help' to see detailed help message"
+		if archiveFormat != "" && !archiveFormats[archiveFormat] {
+			return fmt.Errorf("unsupported --format %q, expected one of tar.gz, zip, oci", archiveFormat)
+		}
+		if len(modelURLs) > 0 {
+			if location != "" || len(args) > 0 {
+				return fmt.Errorf("--url cannot be combined with a file or directory\n\n%v", errMsg)
+			}
+			return nil
+		}
 		if location == "" && len(args) == 0 {
 			return fmt.Errorf("[ file | filepath ] isn't specified\n\n%v", errMsg)
 		} else if len(args) > 1 {
@@ -50,6 +85,24 @@ var importModelCmd = &cobra.Command{
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if len(modelURLs) > 0 {
+			for _, rawURL := range modelURLs {
+				if err := validateURLScheme(rawURL); err != nil {
+					return err
+				}
+			}
+			uploadCtx, cancel := context.WithTimeout(signalCtx, importTimeout)
+			defer cancel()
+			if err := registerModelURLs(uploadCtx, signalCtx, modelURLs); err != nil {
+				utils.Log.Error(err)
+				return err
+			}
+			return nil
+		}
+
 		var path string
 		if location != "" {
 			path = location
@@ -62,26 +115,60 @@ var importModelCmd = &cobra.Command{
 			return fmt.Errorf("could not access the specified path: %v", err)
 		}
 
-		var tarData []byte
-		var fileName string
-
 		if info.IsDir() {
-			tarData, err = compressDirectory(path)
+			format := archiveFormat
+			if format == "" {
+				format = detectArchiveFormat(path)
+			}
+
+			if format == formatTarGz {
+				// Compression and upload are pipelined through a single
+				// io.Pipe in registerModelDirectory, so they share one
+				// deadline here rather than the compress-then-upload split
+				// used for zip/oci below.
+				importCtx, cancel := context.WithTimeout(signalCtx, importTimeout)
+				defer cancel()
+				fileName := filepath.Base(path) + ".tar.gz"
+				if err := registerModelDirectory(importCtx, signalCtx, path, fileName); err != nil {
+					utils.Log.Error(err)
+					return err
+				}
+				return nil
+			}
+
+			var tarData []byte
+			var fileName string
+			compressCtx, cancel := context.WithTimeout(signalCtx, importTimeout)
+			switch format {
+			case formatZip:
+				tarData, err = compressDirectoryZip(compressCtx, path)
+				fileName = filepath.Base(path) + ".zip"
+			case formatOCI:
+				tarData, err = compressOCILayout(compressCtx, path)
+				fileName = filepath.Base(path) + ".tar"
+			}
+			cancel()
 			if err != nil {
 				return err
 			}
-			fileName = filepath.Base(path) + ".tar.gz"
-		} else {
-			fileData, err := os.ReadFile(path)
-			if err != nil {
-				return fmt.Errorf("could not read the specified file: %v", err)
+
+			uploadCtx, cancel := context.WithTimeout(signalCtx, importTimeout)
+			defer cancel()
+			if err := registerModel(uploadCtx, signalCtx, tarData, fileName, "file"); err != nil {
+				utils.Log.Error(err)
+				return err
 			}
-			tarData = fileData
-			fileName = filepath.Base(path)
+			return nil
 		}
 
-		err = registerModel(tarData, fileName, "file")
+		fileData, err := os.ReadFile(path)
 		if err != nil {
+			return fmt.Errorf("could not read the specified file: %v", err)
+		}
+
+		uploadCtx, cancel := context.WithTimeout(signalCtx, importTimeout)
+		defer cancel()
+		if err := registerModel(uploadCtx, signalCtx, fileData, filepath.Base(path), "file"); err != nil {
 			utils.Log.Error(err)
 			return err
 		}
@@ -89,107 +176,336 @@ var importModelCmd = &cobra.Command{
 	},
 }
 
-func compressDirectory(dirpath string) ([]byte, error) {
-	tw := meshkitutils.NewTarWriter()
-	defer tw.Close()
+// ctxError turns a context error into a message clear about why the request
+// stopped, distinguishing a user-initiated Ctrl-C from a --timeout expiry.
+// It's shared across import.go/jobs.go's several independently-timed
+// contexts (upload, compress, job status fetch), so it doesn't name a
+// specific --timeout value.
+func ctxError(ctx context.Context) error {
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return fmt.Errorf("context deadline exceeded: timed out waiting for a response")
+	case context.Canceled:
+		return fmt.Errorf("canceled by user")
+	default:
+		return ctx.Err()
+	}
+}
+
+// validateURLScheme ensures a --url value is fetchable before it's handed off
+// to the server, so obviously bad input (typos, local paths) fails fast.
+func validateURLScheme(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid url %q: %v", rawURL, err)
+	}
+	if !allowedURLSchemes[parsed.Scheme] {
+		return fmt.Errorf("unsupported url scheme %q in %q, expected one of http, https", parsed.Scheme, rawURL)
+	}
+	return nil
+}
+
+// progressBarsEnabled reports whether compress/upload progress bars should be
+// rendered, honoring --quiet/--no-progress and disabling automatically when
+// stderr isn't a terminal.
+func progressBarsEnabled() bool {
+	if quiet || noProgress {
+		return false
+	}
+	return term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+func newByteProgressBar(label string, total int64) *pb.ProgressBar {
+	bar := pb.New64(total)
+	bar.Set(pb.Bytes, true)
+	bar.SetTemplateString(fmt.Sprintf(`{{ green "%s:" }} {{ bar . }} {{ percent . }} {{ speed . }}`, label))
+	bar.SetWriter(os.Stderr)
+	return bar.Start()
+}
+
+// writeTarGz streams dirpath into w as a gzip-compressed tar archive,
+// reading and writing one file at a time so a directory with many or large
+// files never needs to be held in memory as a whole.
+func writeTarGz(ctx context.Context, dirpath string, w io.Writer, bar *pb.ProgressBar) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
 
 	err := filepath.Walk(dirpath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return meshkitutils.ErrFileWalkDir(err, path)
 		}
 
+		if ctx.Err() != nil {
+			return ctxError(ctx)
+		}
+
 		if info.IsDir() {
 			return nil
 		}
 
-		file, err := os.Open(path)
+		relPath, err := filepath.Rel(filepath.Dir(dirpath), path)
 		if err != nil {
-			return handlers.ErrOpenFile(path)
+			return meshkitutils.ErrRelPath(err, path)
 		}
-		defer file.Close()
 
-		fileData, err := io.ReadAll(file)
+		header, err := tar.FileInfoHeader(info, "")
 		if err != nil {
-			return meshkitutils.ErrReadFile(err, path)
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
 		}
 
-		relPath, err := filepath.Rel(filepath.Dir(dirpath), path)
+		file, err := os.Open(path)
 		if err != nil {
-			return meshkitutils.ErrRelPath(err, path)
+			return handlers.ErrOpenFile(path)
 		}
+		defer file.Close()
 
-		if err := tw.Compress(relPath, fileData); err != nil {
-			return err
+		written, err := io.Copy(tarWriter, file)
+		if err != nil {
+			return meshkitutils.ErrCopyFile(err)
+		}
+
+		if bar != nil {
+			bar.Add64(written)
 		}
 
 		return nil
 	})
-
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var buf bytes.Buffer
-	gzipWriter := gzip.NewWriter(&buf)
-	_, err = io.Copy(gzipWriter, tw.Buffer)
-	if err != nil {
-		return nil, meshkitutils.ErrCopyFile(err)
+	if err := tarWriter.Close(); err != nil {
+		return meshkitutils.ErrCloseFile(err)
 	}
-	if err := gzipWriter.Close(); err != nil {
-		return nil, meshkitutils.ErrCloseFile(err)
+	return gzipWriter.Close()
+}
+
+// writeStreamedImportBody writes the wire-compatible JSON body of a
+// directory import directly to w, base64-encoding the tar.gz produced by
+// writeTarGz as it's generated. Only the bytes of the file currently being
+// archived are ever held in memory; the archive and its base64 form are
+// never buffered in full.
+func writeStreamedImportBody(ctx context.Context, w io.Writer, dirpath, fileName string, async bool, bar *pb.ProgressBar) error {
+	if _, err := io.WriteString(w, `{"importBody":{"model_file":"`); err != nil {
+		return err
 	}
 
-	return buf.Bytes(), nil
-}
+	encoder := base64.NewEncoder(base64.StdEncoding, w)
+	if err := writeTarGz(ctx, dirpath, encoder, bar); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
 
-func registerModel(data []byte, name string, dataType string) error {
-	mctlCfg, err := config.GetMesheryCtl(viper.GetViper())
+	fileNameJSON, err := json.Marshal(fileName)
 	if err != nil {
 		return err
 	}
+	if _, err := fmt.Fprintf(w, `","file_name":%s},"uploadType":"file"`, fileNameJSON); err != nil {
+		return err
+	}
+	if async {
+		if _, err := io.WriteString(w, `,"async":true`); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, `}`)
+	return err
+}
 
-	baseURL := mctlCfg.GetBaseMesheryURL()
-	url := baseURL + "/api/meshmodels/register"
+// streamDirectoryRegister compresses and uploads a directory import through
+// a single io.Pipe, so a directory with many or large files is never held in
+// memory as a whole tar.gz or as its base64-inflated JSON body: compression,
+// encoding and upload all happen concurrently, one file at a time.
+func streamDirectoryRegister(ctx context.Context, dirpath, fileName string, async bool) ([]byte, error) {
+	var bar *pb.ProgressBar
+	if progressBarsEnabled() {
+		var totalSize int64
+		err := filepath.Walk(dirpath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return meshkitutils.ErrFileWalkDir(err, path)
+			}
+			if !info.IsDir() {
+				totalSize += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		bar = newByteProgressBar("Compressing and uploading", totalSize)
+		defer bar.Finish()
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeStreamedImportBody(ctx, pw, dirpath, fileName, async, bar))
+	}()
+
+	return postRegisterRequestBody(ctx, pr, -1)
+}
+
+// registerSource produces the bytes of a register request, either a fully
+// buffered ImportRequestBody or one streamed directly from disk, so large
+// directory imports are never held in memory in full.
+type registerSource interface {
+	submit(ctx context.Context, async bool) ([]byte, error)
+}
+
+// bufferedSource submits an ImportRequestBody whose model_file, if any, is
+// already resident in memory: file, URL, and zip/oci archive imports.
+type bufferedSource struct {
+	body ImportRequestBody
+}
+
+func (s bufferedSource) submit(ctx context.Context, async bool) ([]byte, error) {
+	s.body.Async = async
+	return postRegisterRequest(ctx, s.body)
+}
+
+// streamedDirSource submits a directory as a streamed tar.gz, never
+// buffering the archive or its base64 form in memory.
+type streamedDirSource struct {
+	dirpath  string
+	fileName string
+}
+
+func (s streamedDirSource) submit(ctx context.Context, async bool) ([]byte, error) {
+	return streamDirectoryRegister(ctx, s.dirpath, s.fileName, async)
+}
+
+func registerModel(ctx, pollCtx context.Context, data []byte, name string, dataType string) error {
 	importRequest := ImportRequestBody{
 		UploadType: dataType,
 	}
 	importRequest.ImportBody.ModelFile = data
 	importRequest.ImportBody.FileName = name
+	return runRegister(ctx, pollCtx, bufferedSource{body: importRequest})
+}
 
-	requestBody, err := json.Marshal(importRequest)
+// registerModelDirectory registers a directory import, streaming its
+// compression, base64 encoding and upload through a single pipe rather than
+// buffering the archive or its encoded form in memory.
+func registerModelDirectory(ctx, pollCtx context.Context, dirpath, fileName string) error {
+	return runRegister(ctx, pollCtx, streamedDirSource{dirpath: dirpath, fileName: fileName})
+}
+
+// registerModelURLs registers one or more URL-hosted models, issuing a
+// request per URL and rendering each through the same display path as a
+// file import.
+func registerModelURLs(ctx, pollCtx context.Context, urls []string) error {
+	for _, rawURL := range urls {
+		importRequest := ImportRequestBody{
+			UploadType: "urls",
+		}
+		importRequest.ImportBody.URL = rawURL
+		if err := runRegister(ctx, pollCtx, bufferedSource{body: importRequest}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runRegister submits src, either waiting on the synchronous response or,
+// when --async is set, queuing it as a job and optionally polling for the
+// result. ctx governs the submit request and is bounded by --timeout; pollCtx
+// governs the --wait poll loop and is intentionally not, since a job that
+// outlives --timeout is still healthy on the server and the poll should only
+// stop on Ctrl-C (or its own signal cancellation).
+func runRegister(ctx, pollCtx context.Context, src registerSource) error {
+	bodyBytes, err := src.submit(ctx, asyncImport)
 	if err != nil {
 		return err
 	}
 
-	req, err := utils.NewRequest(http.MethodPost, url, bytes.NewReader(requestBody))
+	if !asyncImport {
+		var response models.RegistryAPIResponse
+		if err := meshkitutils.Unmarshal(string(bodyBytes), &response); err != nil {
+			return models.ErrUnmarshal(err, "response body")
+		}
+		displayEntities(&response)
+		return nil
+	}
+
+	var job JobStatus
+	if err := meshkitutils.Unmarshal(string(bodyBytes), &job); err != nil {
+		return models.ErrUnmarshal(err, "response body")
+	}
+	if err := saveJobUUID(job.UUID); err != nil {
+		utils.Log.Error(err)
+	}
+	utils.Log.Infof("import queued as job %s", job.UUID)
+
+	if !waitForJob {
+		return nil
+	}
+
+	response, err := pollJob(pollCtx, job.UUID)
 	if err != nil {
 		return err
 	}
+	displayEntities(response)
+	return removeJobUUID(job.UUID)
+}
+
+// postRegisterRequestBody POSTs body to the register endpoint and returns
+// the response bytes. It is shared by the buffered (postRegisterRequest) and
+// streamed (streamDirectoryRegister) submission paths. A contentLength of -1
+// means body's size isn't known up front; in that case no upload progress
+// bar is attached here, since the caller (streamDirectoryRegister) already
+// tracks compression/upload progress as the archive is generated.
+func postRegisterRequestBody(ctx context.Context, body io.Reader, contentLength int64) ([]byte, error) {
+	mctlCfg, err := config.GetMesheryCtl(viper.GetViper())
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := mctlCfg.GetBaseMesheryURL()
+	url := baseURL + "/api/meshmodels/register"
+
+	if contentLength >= 0 && progressBarsEnabled() {
+		bar := newByteProgressBar("Uploading", contentLength)
+		defer bar.Finish()
+		body = bar.NewProxyReader(body)
+	}
+
+	req, err := utils.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := utils.MakeRequest(req)
 	if err != nil {
-		return err
+		if ctx.Err() != nil {
+			return nil, ctxError(ctx)
+		}
+		return nil, err
 	}
 
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		err = models.ErrDoRequest(err, resp.Request.Method, url)
-		return err
+		return nil, models.ErrDoRequest(err, resp.Request.Method, url)
 	}
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		err = models.ErrDataRead(err, "response body")
-		return err
+		return nil, models.ErrDataRead(err, "response body")
 	}
-	var response models.RegistryAPIResponse
+	return bodyBytes, nil
+}
 
-	if err := meshkitutils.Unmarshal(string(bodyBytes), &response); err != nil {
-		err = models.ErrUnmarshal(err, "response body")
-		return err
+func postRegisterRequest(ctx context.Context, importRequest ImportRequestBody) ([]byte, error) {
+	requestBody, err := json.Marshal(importRequest)
+	if err != nil {
+		return nil, err
 	}
-	displayEntities(&response)
-	return nil
+	return postRegisterRequestBody(ctx, bytes.NewReader(requestBody), int64(len(requestBody)))
 }
 
 func displayEntities(response *models.RegistryAPIResponse) {
@@ -449,4 +765,11 @@ func init() {
 	})
 
 	importModelCmd.Flags().StringVarP(&location, "file", "f", "", "Specify path to the file or directory")
+	importModelCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress progress bar output")
+	importModelCmd.Flags().BoolVar(&noProgress, "no-progress", false, "Disable the compress/upload progress bar")
+	importModelCmd.Flags().StringArrayVar(&modelURLs, "url", []string{}, "Specify one or more URLs to import models from (Git repos, OCI artifacts, or raw HTTP-hosted tarballs)")
+	importModelCmd.Flags().BoolVar(&asyncImport, "async", false, "Queue the import as a background job and return immediately")
+	importModelCmd.Flags().BoolVar(&waitForJob, "wait", false, "With --async, poll the job until it finishes and display its result")
+	importModelCmd.Flags().DurationVar(&importTimeout, "timeout", 10*time.Minute, "Time to allow each of compression and upload to complete before aborting (a tar.gz directory import streams both under a single deadline)")
+	importModelCmd.Flags().StringVar(&archiveFormat, "format", "", "Archive format to compress a directory import with: tar.gz, zip, or oci (auto-detected from the directory contents when omitted)")
 }
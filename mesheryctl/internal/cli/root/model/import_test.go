@@ -0,0 +1,26 @@
+package model
+
+import "testing"
+
+func TestValidateURLScheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawURL  string
+		wantErr bool
+	}{
+		{name: "https", rawURL: "https://example.com/model.tar.gz", wantErr: false},
+		{name: "http", rawURL: "http://example.com/model.tar.gz", wantErr: false},
+		{name: "ftp scheme rejected", rawURL: "ftp://example.com/model.tar.gz", wantErr: true},
+		{name: "local path", rawURL: "/home/user/model.tar.gz", wantErr: true},
+		{name: "malformed url", rawURL: "://bad", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateURLScheme(tt.rawURL)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateURLScheme(%q) error = %v, wantErr %v", tt.rawURL, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/layer5io/meshery/mesheryctl/internal/cli/root/config"
+	"github.com/layer5io/meshery/mesheryctl/pkg/utils"
+	"github.com/layer5io/meshery/server/models"
+	meshkitutils "github.com/layer5io/meshkit/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	jobStatusQueued    = "queued"
+	jobStatusRunning   = "running"
+	jobStatusSucceeded = "succeeded"
+	jobStatusFailed    = "failed"
+)
+
+const (
+	jobPollInterval = 250 * time.Millisecond
+	jobPollMaxDelay = 5 * time.Second
+)
+
+// jobsTimeout bounds a single status fetch in 'jobs get'/'jobs list', not a
+// --wait poll loop (which relies on Ctrl-C/SIGTERM instead, see runRegister).
+var jobsTimeout time.Duration
+
+// JobStatus mirrors the response from GET /api/meshmodels/jobs/:uuid.
+type JobStatus struct {
+	UUID     string                      `json:"uuid"`
+	Status   string                      `json:"status"`
+	Progress int                         `json:"progress"`
+	Result   *models.RegistryAPIResponse `json:"result,omitempty"`
+}
+
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Inspect asynchronous model import jobs",
+	Long:  "Check on or reattach to model imports queued with 'model import --async'.",
+}
+
+var jobsGetCmd = &cobra.Command{
+	Use:   "get <uuid>",
+	Short: "Get the status of an import job",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		ctx, cancel := context.WithTimeout(signalCtx, jobsTimeout)
+		defer cancel()
+
+		job, err := fetchJobStatus(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		utils.Log.Infof("%s: %s (%d%%)", job.UUID, job.Status, job.Progress)
+		switch job.Status {
+		case jobStatusSucceeded:
+			if job.Result != nil {
+				displayEntities(job.Result)
+			}
+			return removeJobUUID(job.UUID)
+		case jobStatusFailed:
+			if err := removeJobUUID(job.UUID); err != nil {
+				return err
+			}
+			return fmt.Errorf("import job %s failed", job.UUID)
+		}
+		return nil
+	},
+}
+
+var jobsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List locally tracked import jobs",
+	Long:  "List the UUIDs of import jobs queued from this machine (under ~/.meshery/jobs) along with their current status.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		uuids, err := listSavedJobUUIDs()
+		if err != nil {
+			return err
+		}
+		if len(uuids) == 0 {
+			utils.Log.Info("no tracked import jobs")
+			return nil
+		}
+
+		signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		header := []string{"UUID", "Status", "Progress"}
+		rows := [][]string{}
+		for _, uuid := range uuids {
+			ctx, cancel := context.WithTimeout(signalCtx, jobsTimeout)
+			job, err := fetchJobStatus(ctx, uuid)
+			cancel()
+			if err != nil {
+				rows = append(rows, []string{uuid, "unknown", "-"})
+				continue
+			}
+			rows = append(rows, []string{job.UUID, job.Status, strconv.Itoa(job.Progress) + "%"})
+		}
+		utils.PrintToTable(header, rows)
+		return nil
+	},
+}
+
+func fetchJobStatus(ctx context.Context, uuid string) (*JobStatus, error) {
+	mctlCfg, err := config.GetMesheryCtl(viper.GetViper())
+	if err != nil {
+		return nil, err
+	}
+
+	url := mctlCfg.GetBaseMesheryURL() + "/api/meshmodels/jobs/" + uuid
+	req, err := utils.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := utils.MakeRequest(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctxError(ctx)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, models.ErrDoRequest(err, resp.Request.Method, url)
+	}
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, models.ErrDataRead(err, "response body")
+	}
+
+	var job JobStatus
+	if err := meshkitutils.Unmarshal(string(bodyBytes), &job); err != nil {
+		return nil, models.ErrUnmarshal(err, "response body")
+	}
+	return &job, nil
+}
+
+// pollJob polls a job's status with exponential backoff (250ms up to a 5s
+// cap) until it succeeds or fails, or ctx is canceled/times out.
+func pollJob(ctx context.Context, uuid string) (*models.RegistryAPIResponse, error) {
+	delay := jobPollInterval
+	for {
+		if ctx.Err() != nil {
+			return nil, ctxError(ctx)
+		}
+
+		job, err := fetchJobStatus(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+		switch job.Status {
+		case jobStatusSucceeded:
+			if job.Result == nil {
+				if err := removeJobUUID(uuid); err != nil {
+					return nil, err
+				}
+				return nil, fmt.Errorf("import job %s succeeded but reported no result", uuid)
+			}
+			return job.Result, nil
+		case jobStatusFailed:
+			if err := removeJobUUID(uuid); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("import job %s failed", uuid)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctxError(ctx)
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > jobPollMaxDelay {
+			delay = jobPollMaxDelay
+		}
+	}
+}
+
+// jobsDir returns (creating if necessary) the directory job UUIDs are
+// tracked under. Each queued job gets an empty marker file named after its
+// UUID, written by saveJobUUID and removed once 'jobs get'/'--wait' observes
+// a terminal status; 'jobs list' reads this directory to find jobs to check
+// on after the CLI that queued them has exited.
+func jobsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".meshery", "jobs")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func saveJobUUID(uuid string) error {
+	dir, err := jobsDir()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, uuid), nil, 0644)
+}
+
+func removeJobUUID(uuid string) error {
+	dir, err := jobsDir()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(dir, uuid))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func listSavedJobUUIDs() ([]string, error) {
+	dir, err := jobsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	uuids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			uuids = append(uuids, entry.Name())
+		}
+	}
+	return uuids, nil
+}
+
+func init() {
+	jobsCmd.PersistentFlags().DurationVar(&jobsTimeout, "timeout", 30*time.Second, "Time to allow a job status fetch to complete before aborting")
+	jobsCmd.AddCommand(jobsGetCmd)
+	jobsCmd.AddCommand(jobsListCmd)
+	ModelCmd.AddCommand(jobsCmd)
+}